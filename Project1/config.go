@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// MLFQConfig configures an MLFQScheduler. It is loaded from a JSON file
+// via -mlfq-config, e.g.:
+//
+//	{"queues": 4, "baseQuantum": 4, "boostInterval": 50}
+type MLFQConfig struct {
+	// Queues is the number of priority levels, 0 (highest) through
+	// Queues-1 (lowest).
+	Queues int `json:"queues"`
+	// BaseQuantum is the time quantum of queue 0; each lower queue's
+	// quantum doubles, so queue i gets BaseQuantum*2^i ticks.
+	BaseQuantum int64 `json:"baseQuantum"`
+	// BoostInterval is how often, in ticks, every ready process is moved
+	// back to queue 0 to prevent starvation. Zero disables boosting.
+	BoostInterval int64 `json:"boostInterval"`
+}
+
+// DefaultMLFQConfig is used when -mlfq-config is not given.
+var DefaultMLFQConfig = MLFQConfig{Queues: 3, BaseQuantum: 4, BoostInterval: 50}
+
+// loadMLFQConfig reads an MLFQConfig from the JSON file at path.
+func loadMLFQConfig(path string) (MLFQConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return MLFQConfig{}, fmt.Errorf("%v: error opening MLFQ config", err)
+	}
+	defer f.Close()
+
+	cfg := DefaultMLFQConfig
+	if err := json.NewDecoder(f).Decode(&cfg); err != nil {
+		return MLFQConfig{}, fmt.Errorf("%v: error parsing MLFQ config", err)
+	}
+
+	return cfg, nil
+}
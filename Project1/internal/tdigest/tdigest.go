@@ -0,0 +1,166 @@
+// Package tdigest implements Ted Dunning's t-digest, a compact, mergeable
+// summary of a stream of samples that supports accurate quantile queries
+// in roughly constant space, with the highest resolution near the tails
+// (p99, p999, ...) rather than the median.
+package tdigest
+
+import (
+	"math"
+	"sort"
+)
+
+// bufferSize is how many raw samples Add buffers before folding them into
+// the sorted, merged centroid list.
+const bufferSize = 256
+
+type centroid struct {
+	mean  float64
+	count float64
+}
+
+// Digest accumulates samples into a small set of weighted centroids.
+// The zero value is not usable; construct one with New.
+type Digest struct {
+	delta     float64
+	centroids []centroid // sorted by mean, already merged
+	buffer    []centroid // newly added singletons, not yet merged in
+	count     float64
+}
+
+// New returns an empty Digest using compression parameter delta. Larger
+// delta yields tighter quantile estimates at the cost of more centroids;
+// delta of around 100 is a common default.
+func New(delta float64) *Digest {
+	return &Digest{delta: delta}
+}
+
+// Count returns the number of samples added so far.
+func (d *Digest) Count() float64 {
+	return d.count
+}
+
+// Add records a single sample. The sample is buffered and folded into the
+// digest's centroids the next time the buffer fills or a query is made.
+func (d *Digest) Add(x float64) {
+	d.buffer = append(d.buffer, centroid{mean: x, count: 1})
+	d.count++
+	if len(d.buffer) >= bufferSize {
+		d.compress()
+	}
+}
+
+// compress sorts the pending buffer together with the existing centroids
+// by mean and merges adjacent points in a single left-to-right pass,
+// folding each point into the running centroid as long as doing so stays
+// under that centroid's capacity. Capacity comes from Dunning's scaling
+// function k(q, delta) = (delta/2pi) * asin(2q-1): a centroid estimated to
+// sit at quantile q may hold at most 4*N*q*(1-q)/delta samples, so
+// centroids near the median absorb far more points than ones out at the
+// tails, which is what gives t-digest its extra tail resolution.
+func (d *Digest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+
+	all := make([]centroid, 0, len(d.centroids)+len(d.buffer))
+	all = append(all, d.centroids...)
+	all = append(all, d.buffer...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := all[:1]
+	cum := merged[0].count
+	for _, c := range all[1:] {
+		last := &merged[len(merged)-1]
+		q := cum / d.count
+		capacity := 4 * d.count * q * (1 - q) / d.delta
+		if last.count+c.count <= capacity {
+			last.mean += c.count * (c.mean - last.mean) / (last.count + c.count)
+			last.count += c.count
+		} else {
+			merged = append(merged, c)
+		}
+		cum += c.count
+	}
+
+	d.centroids = merged
+	d.buffer = nil
+}
+
+// Min returns the smallest sample added, or 0 if the digest is empty.
+func (d *Digest) Min() float64 {
+	d.compress()
+	if len(d.centroids) == 0 {
+		return 0
+	}
+	return d.centroids[0].mean
+}
+
+// Max returns the largest sample added, or 0 if the digest is empty.
+func (d *Digest) Max() float64 {
+	d.compress()
+	if len(d.centroids) == 0 {
+		return 0
+	}
+	return d.centroids[len(d.centroids)-1].mean
+}
+
+// Quantile returns an estimate of the q-th quantile (0 <= q <= 1) of the
+// samples added so far, linearly interpolating within the centroid that
+// straddles q.
+func (d *Digest) Quantile(q float64) float64 {
+	d.compress()
+
+	switch {
+	case len(d.centroids) == 0:
+		return 0
+	case len(d.centroids) == 1:
+		return d.centroids[0].mean
+	}
+
+	target := q * d.count
+	cum := 0.0
+	for i, c := range d.centroids {
+		next := cum + c.count
+		if next >= target || i == len(d.centroids)-1 {
+			prevMean, nextMean := c.mean, c.mean
+			if i > 0 {
+				prevMean = d.centroids[i-1].mean
+			}
+			if i < len(d.centroids)-1 {
+				nextMean = d.centroids[i+1].mean
+			}
+			frac := (target - cum) / c.count
+			return prevMean + frac*(nextMean-prevMean)
+		}
+		cum = next
+	}
+
+	return d.centroids[len(d.centroids)-1].mean
+}
+
+// Histogram buckets the digest into the given number of equal-width bins
+// spanning [Min(), Max()] and returns the approximate sample count in each
+// bin, distributing each centroid's count into the bin its mean falls in.
+func (d *Digest) Histogram(buckets int) []int64 {
+	d.compress()
+
+	counts := make([]int64, buckets)
+	if len(d.centroids) == 0 || buckets == 0 {
+		return counts
+	}
+
+	lo, hi := d.Min(), d.Max()
+	width := hi - lo
+	for _, c := range d.centroids {
+		bucket := 0
+		if width > 0 {
+			bucket = int((c.mean - lo) / width * float64(buckets))
+			if bucket >= buckets {
+				bucket = buckets - 1
+			}
+		}
+		counts[bucket] += int64(math.Round(c.count))
+	}
+
+	return counts
+}
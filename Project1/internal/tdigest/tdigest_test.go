@@ -0,0 +1,52 @@
+package tdigest
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDigestQuantileUniform(t *testing.T) {
+	d := New(100)
+	const n = 1000
+	for i := 0; i < n; i++ {
+		d.Add(float64(i))
+	}
+
+	tests := []struct {
+		q    float64
+		want float64
+	}{
+		{q: 0.50, want: 500},
+		{q: 0.90, want: 900},
+		{q: 0.99, want: 990},
+	}
+	for _, tt := range tests {
+		if got := d.Quantile(tt.q); math.Abs(got-tt.want) > 20 {
+			t.Errorf("Quantile(%v) = %v, want within 20 of %v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestDigestMinMax(t *testing.T) {
+	d := New(100)
+	for _, x := range []float64{3, 1, 4, 1, 5, 9, 2, 6} {
+		d.Add(x)
+	}
+
+	if got := d.Min(); got != 1 {
+		t.Errorf("Min() = %v, want 1", got)
+	}
+	if got := d.Max(); got != 9 {
+		t.Errorf("Max() = %v, want 9", got)
+	}
+}
+
+func TestDigestCount(t *testing.T) {
+	d := New(100)
+	for i := 0; i < 42; i++ {
+		d.Add(float64(i))
+	}
+	if got := d.Count(); got != 42 {
+		t.Errorf("Count() = %v, want 42", got)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/TiceShark/CSCE4600-003/Project1/internal/tdigest"
+)
+
+// MLFQScheduler implements multi-level feedback queue scheduling: each
+// process starts in the highest-priority queue and is demoted to the next
+// queue down (with a doubled time quantum) whenever it uses up its
+// quantum without finishing, while a periodic priority boost moves every
+// waiting process back to the top queue to prevent starvation. A process
+// blocked on I/O is neither demoted nor boosted while blocked; it returns
+// to the queue level it had when it blocked. See Scheduler for how I/O
+// blocking is handled.
+type MLFQScheduler struct {
+	Config MLFQConfig
+}
+
+func (MLFQScheduler) Name() string { return "Multi-level feedback queue" }
+
+func (s MLFQScheduler) Run(processes []Process) ([]TimeSlice, [][]string, Stats) {
+	cfg := s.Config
+	if cfg.Queues <= 0 {
+		cfg.Queues = DefaultMLFQConfig.Queues
+	}
+	if cfg.BaseQuantum <= 0 {
+		cfg.BaseQuantum = DefaultMLFQConfig.BaseQuantum
+	}
+
+	quantumAt := func(level int) int64 { return cfg.BaseQuantum << uint(level) }
+
+	n := len(processes)
+	var (
+		tracker  = make([]RunTime, n)
+		level    = make([]int, n)
+		arrived  = make([]bool, n)
+		queues   = make([][]int64, cfg.Queues)
+		totalRun int64
+		maxArr   int64
+	)
+	for i := range processes {
+		tracker[i].ProcessID = processes[i].ProcessID
+		tracker[i].remainTime = processes[i].BurstDuration
+		totalRun += processes[i].BurstDuration
+		for _, burst := range processes[i].IOBursts {
+			totalRun += burst.Duration
+		}
+		if processes[i].ArrivalTime > maxArr {
+			maxArr = processes[i].ArrivalTime
+		}
+	}
+
+	enqueue := func(idx int64, lvl int) {
+		level[idx] = lvl
+		queues[lvl] = append(queues[lvl], idx)
+	}
+
+	popNext := func() (int64, bool) {
+		for lvl := range queues {
+			if len(queues[lvl]) > 0 {
+				idx := queues[lvl][0]
+				queues[lvl] = queues[lvl][1:]
+				return idx, true
+			}
+		}
+		return 0, false
+	}
+
+	remaining := func() bool {
+		for i := range processes {
+			if tracker[i].State != StateDone {
+				return true
+			}
+		}
+		return false
+	}
+
+	var (
+		gantt            = make([]TimeSlice, 0)
+		schedule         = make([][]string, n)
+		waitDigest       = tdigest.New(metricDigestDelta)
+		ioWaitDigest     = tdigest.New(metricDigestDelta)
+		turnaroundDigest = tdigest.New(metricDigestDelta)
+		totalWait        float64
+		totalIOWait      float64
+		totalTurnaround  float64
+		lastCompletion   float64
+		active           = int64(-1)
+		activeLevel      = 0
+		quantumLeft      = int64(0)
+		procStart        = int64(0)
+	)
+
+	maxTicks := totalRun + maxArr + 1
+	for t := int64(0); t < maxTicks && remaining(); t++ {
+		for i := range processes {
+			if !arrived[i] && processes[i].ArrivalTime == t {
+				arrived[i] = true
+				enqueue(int64(i), 0)
+			}
+		}
+
+		for _, idx := range advanceBlocked(tracker) {
+			enqueue(idx, level[idx])
+		}
+
+		if cfg.BoostInterval > 0 && t > 0 && t%cfg.BoostInterval == 0 {
+			for lvl := 1; lvl < len(queues); lvl++ {
+				for _, idx := range queues[lvl] {
+					enqueue(idx, 0)
+				}
+				queues[lvl] = queues[lvl][:0]
+			}
+		}
+
+		if active == -1 {
+			if idx, ok := popNext(); ok {
+				active = idx
+				tracker[active].State = StateRunning
+				activeLevel = level[idx]
+				quantumLeft = quantumAt(activeLevel)
+				procStart = t
+			}
+		}
+
+		for i := range processes {
+			if int64(i) != active && arrived[i] && tracker[i].remainTime > 0 && tracker[i].State != StateBlocked {
+				tracker[i].waitTime++
+			}
+		}
+
+		if active == -1 {
+			continue
+		}
+
+		tracker[active].remainTime--
+		tracker[active].ranTime++
+		quantumLeft--
+
+		finished := tracker[active].remainTime == 0
+		blocked := !finished && triggerIOBurst(processes, tracker, active)
+		expired := !finished && !blocked && quantumLeft == 0
+
+		if finished {
+			tracker[active].State = StateDone
+		}
+
+		if finished || blocked || expired {
+			gantt = append(gantt, TimeSlice{PID: processes[active].ProcessID, Start: procStart, Stop: t + 1})
+
+			if expired {
+				tracker[active].State = StateReady
+				next := activeLevel + 1
+				if next >= cfg.Queues {
+					next = cfg.Queues - 1
+				}
+				enqueue(active, next)
+			}
+			active = -1
+		}
+	}
+
+	for i := range processes {
+		waitingTime := tracker[i].waitTime
+		totalWait += float64(waitingTime)
+		waitDigest.Add(float64(waitingTime))
+
+		ioWait := tracker[i].ioWait
+		totalIOWait += float64(ioWait)
+		ioWaitDigest.Add(float64(ioWait))
+
+		turnaround := processes[i].BurstDuration + waitingTime + ioWait
+		totalTurnaround += float64(turnaround)
+		turnaroundDigest.Add(float64(turnaround))
+
+		completion := processes[i].ArrivalTime + turnaround
+		if float64(completion) > lastCompletion {
+			lastCompletion = float64(completion)
+		}
+
+		schedule[i] = []string{
+			fmt.Sprint(processes[i].ProcessID),
+			fmt.Sprint(processes[i].Priority),
+			fmt.Sprint(processes[i].BurstDuration),
+			fmt.Sprint(processes[i].ArrivalTime),
+			fmt.Sprint(waitingTime),
+			fmt.Sprint(ioWait),
+			fmt.Sprint(turnaround),
+			fmt.Sprint(completion),
+		}
+	}
+
+	count := float64(n)
+	stats := buildStats(waitDigest, ioWaitDigest, turnaroundDigest, totalWait, totalIOWait, totalTurnaround, count, lastCompletion)
+
+	return gantt, schedule, stats
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/TiceShark/CSCE4600-003/Project1/internal/tdigest"
+)
+
+// SJFPriorityScheduler implements preemptive priority scheduling, falling
+// back to shortest-job-first to break ties between equal priorities. See
+// Scheduler for how I/O blocking is handled.
+type SJFPriorityScheduler struct{}
+
+func (SJFPriorityScheduler) Name() string { return "Priority" }
+
+func (SJFPriorityScheduler) Run(processes []Process) ([]TimeSlice, [][]string, Stats) {
+	n := len(processes)
+	var (
+		tracker          = make([]RunTime, n)
+		schedule         = make([][]string, n)
+		gantt            = make([]TimeSlice, 0)
+		waitDigest       = tdigest.New(metricDigestDelta)
+		ioWaitDigest     = tdigest.New(metricDigestDelta)
+		turnaroundDigest = tdigest.New(metricDigestDelta)
+		totalWait        float64
+		totalIOWait      float64
+		totalTurnaround  float64
+		lastCompletion   float64
+		active           = int64(-1)
+		procStart        int64
+		maxArr           int64
+		totalRun         int64
+	)
+
+	for i := range processes {
+		tracker[i].ProcessID = processes[i].ProcessID
+		tracker[i].remainTime = processes[i].BurstDuration
+		totalRun += processes[i].BurstDuration
+		for _, burst := range processes[i].IOBursts {
+			totalRun += burst.Duration
+		}
+		if processes[i].ArrivalTime > maxArr {
+			maxArr = processes[i].ArrivalTime
+		}
+	}
+
+	remaining := func() bool {
+		for i := range processes {
+			if tracker[i].State != StateDone {
+				return true
+			}
+		}
+		return false
+	}
+
+	maxTicks := totalRun + maxArr + 1
+	for t := int64(0); t < maxTicks && remaining(); t++ {
+		readied := advanceBlocked(tracker)
+
+		arrivedNow := false
+		for i := range processes {
+			if processes[i].ArrivalTime == t {
+				arrivedNow = true
+			}
+		}
+
+		if arrivedNow || len(readied) > 0 || active == -1 {
+			if candidate := getHighest(tracker, processes, t); candidate != active {
+				if active != -1 {
+					tracker[active].State = StateReady
+					gantt = append(gantt, TimeSlice{PID: processes[active].ProcessID, Start: procStart, Stop: t})
+				}
+				procStart = t
+				active = candidate
+				if active != -1 {
+					tracker[active].State = StateRunning
+				}
+			}
+		}
+
+		for i := range processes {
+			if int64(i) != active && tracker[i].State == StateReady && processes[i].ArrivalTime <= t {
+				tracker[i].waitTime++
+			}
+		}
+
+		if active == -1 {
+			continue
+		}
+
+		tracker[active].remainTime--
+		tracker[active].ranTime++
+
+		if tracker[active].remainTime == 0 {
+			tracker[active].State = StateDone
+			gantt = append(gantt, TimeSlice{PID: processes[active].ProcessID, Start: procStart, Stop: t + 1})
+			active = -1
+		} else if triggerIOBurst(processes, tracker, active) {
+			gantt = append(gantt, TimeSlice{PID: processes[active].ProcessID, Start: procStart, Stop: t + 1})
+			active = -1
+		}
+	}
+
+	for i := range processes {
+		waitingTime := tracker[i].waitTime
+		totalWait += float64(waitingTime)
+		waitDigest.Add(float64(waitingTime))
+
+		ioWait := tracker[i].ioWait
+		totalIOWait += float64(ioWait)
+		ioWaitDigest.Add(float64(ioWait))
+
+		turnaround := processes[i].BurstDuration + waitingTime + ioWait
+		totalTurnaround += float64(turnaround)
+		turnaroundDigest.Add(float64(turnaround))
+
+		completion := processes[i].ArrivalTime + turnaround
+		if float64(completion) > lastCompletion {
+			lastCompletion = float64(completion)
+		}
+
+		schedule[i] = []string{
+			fmt.Sprint(processes[i].ProcessID),
+			fmt.Sprint(processes[i].Priority),
+			fmt.Sprint(processes[i].BurstDuration),
+			fmt.Sprint(processes[i].ArrivalTime),
+			fmt.Sprint(waitingTime),
+			fmt.Sprint(ioWait),
+			fmt.Sprint(turnaround),
+			fmt.Sprint(completion),
+		}
+	}
+
+	count := float64(n)
+	stats := buildStats(waitDigest, ioWaitDigest, turnaroundDigest, totalWait, totalIOWait, totalTurnaround, count, lastCompletion)
+
+	return gantt, schedule, stats
+}
+
+// getHighest returns the index of the highest-priority ready process
+// (arrived, not blocked, not finished) at current, breaking ties by
+// shortest remaining time, or -1 if none is ready.
+func getHighest(tracker []RunTime, processes []Process, current int64) int64 {
+	highest := int64(-1)
+
+	for i := range processes {
+		if tracker[i].remainTime <= 0 || tracker[i].State == StateBlocked || processes[i].ArrivalTime > current {
+			continue
+		}
+		switch {
+		case highest == -1:
+			highest = int64(i)
+		case processes[i].Priority < processes[highest].Priority:
+			highest = int64(i)
+		case processes[i].Priority == processes[highest].Priority && tracker[i].remainTime < tracker[highest].remainTime:
+			highest = int64(i)
+		}
+	}
+
+	return highest
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/TiceShark/CSCE4600-003/Project1/internal/tdigest"
+)
+
+// DefaultQuantum is the RRScheduler time quantum used when none is given
+// via the -quantum flag.
+const DefaultQuantum = 4
+
+// RRScheduler implements round-robin scheduling: each ready process runs
+// for at most Quantum ticks before the CPU moves to the next one. See
+// Scheduler for how I/O blocking is handled.
+type RRScheduler struct {
+	// Quantum is the number of ticks each process may run before being
+	// preempted. Zero is treated as DefaultQuantum.
+	Quantum int64
+}
+
+func (RRScheduler) Name() string { return "Round-robin" }
+
+func (s RRScheduler) Run(processes []Process) ([]TimeSlice, [][]string, Stats) {
+	quantum := s.Quantum
+	if quantum <= 0 {
+		quantum = DefaultQuantum
+	}
+
+	n := len(processes)
+	var (
+		tracker          = make([]RunTime, n)
+		arrived          = make([]bool, n)
+		queue            = make([]int64, 0, n)
+		schedule         = make([][]string, n)
+		gantt            = make([]TimeSlice, 0)
+		waitDigest       = tdigest.New(metricDigestDelta)
+		ioWaitDigest     = tdigest.New(metricDigestDelta)
+		turnaroundDigest = tdigest.New(metricDigestDelta)
+		totalWait        float64
+		totalIOWait      float64
+		totalTurnaround  float64
+		lastCompletion   float64
+		active           = int64(-1)
+		quantumLeft      int64
+		procStart        int64
+		maxArr           int64
+		totalRun         int64
+	)
+
+	for i := range processes {
+		tracker[i].ProcessID = processes[i].ProcessID
+		tracker[i].remainTime = processes[i].BurstDuration
+		totalRun += processes[i].BurstDuration
+		for _, burst := range processes[i].IOBursts {
+			totalRun += burst.Duration
+		}
+		if processes[i].ArrivalTime > maxArr {
+			maxArr = processes[i].ArrivalTime
+		}
+	}
+
+	remaining := func() bool {
+		for i := range processes {
+			if tracker[i].State != StateDone {
+				return true
+			}
+		}
+		return false
+	}
+
+	maxTicks := totalRun + maxArr + 1
+	for t := int64(0); t < maxTicks && remaining(); t++ {
+		for i := range processes {
+			if !arrived[i] && processes[i].ArrivalTime == t {
+				arrived[i] = true
+				queue = append(queue, int64(i))
+			}
+		}
+
+		for _, idx := range advanceBlocked(tracker) {
+			queue = append(queue, idx)
+		}
+
+		if active == -1 && len(queue) > 0 {
+			active, queue = queue[0], queue[1:]
+			tracker[active].State = StateRunning
+			quantumLeft = quantum
+			procStart = t
+		}
+
+		for i := range processes {
+			if int64(i) != active && arrived[i] && tracker[i].State == StateReady {
+				tracker[i].waitTime++
+			}
+		}
+
+		if active == -1 {
+			continue
+		}
+
+		tracker[active].remainTime--
+		tracker[active].ranTime++
+		quantumLeft--
+
+		finished := tracker[active].remainTime == 0
+		if finished {
+			tracker[active].State = StateDone
+		}
+
+		blocked := !finished && triggerIOBurst(processes, tracker, active)
+		expired := !finished && !blocked && quantumLeft == 0
+
+		if finished || blocked || expired {
+			gantt = append(gantt, TimeSlice{PID: processes[active].ProcessID, Start: procStart, Stop: t + 1})
+
+			if expired {
+				tracker[active].State = StateReady
+				queue = append(queue, active)
+			}
+			active = -1
+		}
+	}
+
+	for i := range processes {
+		waitingTime := tracker[i].waitTime
+		totalWait += float64(waitingTime)
+		waitDigest.Add(float64(waitingTime))
+
+		ioWait := tracker[i].ioWait
+		totalIOWait += float64(ioWait)
+		ioWaitDigest.Add(float64(ioWait))
+
+		turnaround := processes[i].BurstDuration + waitingTime + ioWait
+		totalTurnaround += float64(turnaround)
+		turnaroundDigest.Add(float64(turnaround))
+
+		completion := processes[i].ArrivalTime + turnaround
+		if float64(completion) > lastCompletion {
+			lastCompletion = float64(completion)
+		}
+
+		schedule[i] = []string{
+			fmt.Sprint(processes[i].ProcessID),
+			fmt.Sprint(processes[i].Priority),
+			fmt.Sprint(processes[i].BurstDuration),
+			fmt.Sprint(processes[i].ArrivalTime),
+			fmt.Sprint(waitingTime),
+			fmt.Sprint(ioWait),
+			fmt.Sprint(turnaround),
+			fmt.Sprint(completion),
+		}
+	}
+
+	count := float64(n)
+	stats := buildStats(waitDigest, ioWaitDigest, turnaroundDigest, totalWait, totalIOWait, totalTurnaround, count, lastCompletion)
+
+	return gantt, schedule, stats
+}
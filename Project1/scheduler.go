@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/TiceShark/CSCE4600-003/Project1/internal/tdigest"
+)
+
+// Scheduler is a CPU scheduling algorithm that can be run against a slice
+// of processes. Implementations are registered in schedulerRegistry so
+// they can be selected independently via the -algorithms flag, and so
+// each can be table-tested in isolation without going through main's I/O.
+//
+// Every implementation honors Process.IOBursts the same way: a process
+// blocks for I/O when its IOBursts say to, and the CPU moves on to
+// whichever ready process the algorithm would pick next in the meantime.
+type Scheduler interface {
+	// Name is the human-readable title used as the Gantt chart heading.
+	Name() string
+	// Run executes the algorithm against processes and returns the Gantt
+	// chart slices, the per-process schedule table rows, and the
+	// aggregate wait/turnaround/throughput statistics.
+	Run(processes []Process) (gantt []TimeSlice, rows [][]string, stats Stats)
+}
+
+// schedulerRegistry builds the map of -algorithms keys to Schedulers for a
+// single run, threading in the flags/config that vary per invocation
+// (the RR quantum and the MLFQ configuration).
+func schedulerRegistry(quantum int64, mlfq MLFQConfig) map[string]Scheduler {
+	return map[string]Scheduler{
+		"fcfs":     FCFSScheduler{},
+		"sjf":      SJFScheduler{},
+		"priority": SJFPriorityScheduler{},
+		"rr":       RRScheduler{Quantum: quantum},
+		"mlfq":     MLFQScheduler{Config: mlfq},
+	}
+}
+
+// resolveSchedulers looks up each key in keys against registry, preserving
+// the caller's order, e.g. for the -algorithms fcfs,sjf,rr,mlfq flag.
+func resolveSchedulers(registry map[string]Scheduler, keys []string) ([]Scheduler, error) {
+	schedulers := make([]Scheduler, 0, len(keys))
+	for _, key := range keys {
+		sched, ok := registry[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown scheduler %q", ErrInvalidArgs, key)
+		}
+		schedulers = append(schedulers, sched)
+	}
+
+	return schedulers, nil
+}
+
+// triggerIOBurst checks whether the process at idx has now run exactly as
+// long as its next IOBurst's After, and if so blocks it for that burst's
+// Duration. It returns whether the process blocked.
+func triggerIOBurst(processes []Process, tracker []RunTime, idx int64) bool {
+	t := &tracker[idx]
+	if t.ioIndex >= len(processes[idx].IOBursts) {
+		return false
+	}
+
+	burst := processes[idx].IOBursts[t.ioIndex]
+	if t.ranTime != burst.After {
+		return false
+	}
+
+	t.ioIndex++
+	t.State = StateBlocked
+	t.ioRemaining = burst.Duration
+	return true
+}
+
+// advanceBlocked ticks down every blocked process's remaining I/O time,
+// tallying the tick against its ioWait. A process whose I/O time already
+// ran out in an earlier tick is marked ready instead, and the indices of
+// those are returned so they can be rescheduled this tick.
+func advanceBlocked(tracker []RunTime) []int64 {
+	var readied []int64
+	for i := range tracker {
+		if tracker[i].State != StateBlocked {
+			continue
+		}
+
+		if tracker[i].ioRemaining <= 0 {
+			tracker[i].State = StateReady
+			readied = append(readied, int64(i))
+			continue
+		}
+		tracker[i].ioWait++
+		tracker[i].ioRemaining--
+	}
+
+	return readied
+}
+
+// buildStats assembles a Stats from the running totals a scheduler
+// accumulates over its process loop and the t-digests fed alongside them.
+func buildStats(waitDigest, ioWaitDigest, turnaroundDigest *tdigest.Digest, totalWait, totalIOWait, totalTurnaround, count, lastCompletion float64) Stats {
+	return Stats{
+		Wait:       summarizeMetric(waitDigest, totalWait/count),
+		IOWait:     summarizeMetric(ioWaitDigest, totalIOWait/count),
+		Turnaround: summarizeMetric(turnaroundDigest, totalTurnaround/count),
+		Throughput: count / lastCompletion,
+	}
+}
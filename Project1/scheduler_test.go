@@ -0,0 +1,144 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// rowsByID runs sched against processes and indexes the resulting schedule
+// rows by process ID for easy lookup in table tests.
+func rowsByID(t *testing.T, sched Scheduler, processes []Process) map[int64][]string {
+	t.Helper()
+	_, rows, _ := sched.Run(processes)
+	byID := make(map[int64][]string, len(rows))
+	for _, row := range rows {
+		id, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			t.Fatalf("parsing schedule row ID %q: %v", row[0], err)
+		}
+		byID[id] = row
+	}
+	return byID
+}
+
+func TestFCFSSchedulerOrder(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1},
+		{ProcessID: 3, BurstDuration: 8, ArrivalTime: 2},
+	}
+
+	rows := rowsByID(t, FCFSScheduler{}, processes)
+
+	tests := []struct {
+		id             int64
+		wantWait       string
+		wantTurnaround string
+	}{
+		{id: 1, wantWait: "0", wantTurnaround: "5"},
+		{id: 2, wantWait: "4", wantTurnaround: "7"},
+		{id: 3, wantWait: "6", wantTurnaround: "14"},
+	}
+	for _, tt := range tests {
+		row := rows[tt.id]
+		if row[4] != tt.wantWait {
+			t.Errorf("process %d wait = %s, want %s", tt.id, row[4], tt.wantWait)
+		}
+		if row[6] != tt.wantTurnaround {
+			t.Errorf("process %d turnaround = %s, want %s", tt.id, row[6], tt.wantTurnaround)
+		}
+	}
+}
+
+// TestSchedulersIOBlocking runs every registered scheduler against a
+// workload where two of three processes block on I/O, checking that each
+// reports the expected IO Wait per process with no panics.
+func TestSchedulersIOBlocking(t *testing.T) {
+	schedulers := []Scheduler{
+		FCFSScheduler{},
+		SJFScheduler{},
+		SJFPriorityScheduler{},
+		RRScheduler{Quantum: 4},
+		MLFQScheduler{Config: DefaultMLFQConfig},
+	}
+
+	for _, sched := range schedulers {
+		t.Run(sched.Name(), func(t *testing.T) {
+			processes := []Process{
+				{ProcessID: 1, BurstDuration: 10, ArrivalTime: 0, Priority: 3, IOBursts: []IOBurst{{After: 3, Duration: 4}}},
+				{ProcessID: 2, BurstDuration: 6, ArrivalTime: 1, Priority: 1},
+				{ProcessID: 3, BurstDuration: 8, ArrivalTime: 2, Priority: 2, IOBursts: []IOBurst{{After: 2, Duration: 3}}},
+			}
+
+			rows := rowsByID(t, sched, processes)
+
+			tests := []struct {
+				id         int64
+				wantIOWait string
+			}{
+				{id: 1, wantIOWait: "4"},
+				{id: 2, wantIOWait: "0"},
+				{id: 3, wantIOWait: "3"},
+			}
+			for _, tt := range tests {
+				if got := rows[tt.id][5]; got != tt.wantIOWait {
+					t.Errorf("process %d IO wait = %s, want %s", tt.id, got, tt.wantIOWait)
+				}
+			}
+		})
+	}
+}
+
+// TestFCFSThroughputUsesMaxCompletion guards against regressing to using
+// the last-iterated process's completion time instead of the true
+// maximum, which I/O blocking can make differ from input order.
+func TestFCFSThroughputUsesMaxCompletion(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, IOBursts: []IOBurst{{After: 2, Duration: 2}}},
+		{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1},
+		{ProcessID: 3, BurstDuration: 8, ArrivalTime: 1, IOBursts: []IOBurst{{After: 3, Duration: 4}}},
+		{ProcessID: 4, BurstDuration: 2, ArrivalTime: 3},
+	}
+
+	_, rows, stats := FCFSScheduler{}.Run(processes)
+
+	var maxExit int64
+	for _, row := range rows {
+		exit, err := strconv.ParseInt(row[7], 10, 64)
+		if err != nil {
+			t.Fatalf("parsing exit %q: %v", row[7], err)
+		}
+		if exit > maxExit {
+			maxExit = exit
+		}
+	}
+
+	wantThroughput := float64(len(processes)) / float64(maxExit)
+	if math.Abs(stats.Throughput-wantThroughput) > 1e-9 {
+		t.Errorf("Throughput = %v, want %v (count / true max completion %d)", stats.Throughput, wantThroughput, maxExit)
+	}
+}
+
+// TestMLFQRunsToCompletionPastLongIOBurst guards against maxTicks being
+// bounded only by CPU burst durations: an I/O burst far longer than every
+// process's CPU burst must not cause the simulation loop to exit with
+// that process still blocked, silently truncating its reported ioWait.
+func TestMLFQRunsToCompletionPastLongIOBurst(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 10, ArrivalTime: 0, IOBursts: []IOBurst{{After: 2, Duration: 500}}},
+		{ProcessID: 2, BurstDuration: 5, ArrivalTime: 1},
+	}
+
+	rows := rowsByID(t, MLFQScheduler{Config: DefaultMLFQConfig}, processes)
+
+	if got := rows[1][5]; got != "500" {
+		t.Errorf("process 1 IO wait = %s, want 500", got)
+	}
+	if got := rows[1][6]; got != "510" {
+		t.Errorf("process 1 turnaround = %s, want 510", got)
+	}
+	if got := rows[1][7]; got != "510" {
+		t.Errorf("process 1 completion = %s, want 510", got)
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WorkloadConfig parameterizes the synthetic workload generated by
+// runSimulate: arrivals follow a Poisson process, burst durations are
+// drawn from a configurable distribution, and priorities are drawn from a
+// discrete distribution over weights.
+type WorkloadConfig struct {
+	// Lambda is the Poisson arrival rate, in processes per tick.
+	Lambda float64
+	// BurstDist selects the burst duration distribution: "exp" (default)
+	// or "lognormal".
+	BurstDist string
+	// BurstMean is the mean burst duration, in ticks.
+	BurstMean float64
+	// PriorityWeights gives the relative weight of priority levels 1..N,
+	// where N is len(PriorityWeights).
+	PriorityWeights []float64
+	// IOBurstProb is the probability that a generated process performs a
+	// single I/O burst partway through its CPU burst. Zero means no
+	// generated process ever blocks.
+	IOBurstProb float64
+	// IOBurstMean is the mean duration, in ticks, of a generated I/O
+	// burst.
+	IOBurstMean float64
+}
+
+// generateWorkload draws n processes from cfg using rng, sorted by
+// arrival time as every scheduler in this package expects.
+func generateWorkload(cfg WorkloadConfig, n int, rng *rand.Rand) []Process {
+	processes := make([]Process, n)
+
+	var arrival float64
+	for i := 0; i < n; i++ {
+		arrival += rng.ExpFloat64() / cfg.Lambda
+
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   int64(math.Round(arrival)),
+			BurstDuration: sampleBurst(rng, cfg.BurstDist, cfg.BurstMean),
+			Priority:      samplePriority(rng, cfg.PriorityWeights),
+		}
+
+		if cfg.IOBurstProb > 0 && rng.Float64() < cfg.IOBurstProb {
+			processes[i].IOBursts = []IOBurst{sampleIOBurst(rng, processes[i].BurstDuration, cfg.IOBurstMean)}
+		}
+	}
+
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].ArrivalTime < processes[j].ArrivalTime
+	})
+
+	return processes
+}
+
+// sampleIOBurst draws a single I/O burst that fires partway through a
+// burstDuration-tick CPU burst, with a duration drawn from an exponential
+// distribution with the given mean.
+func sampleIOBurst(rng *rand.Rand, burstDuration int64, mean float64) IOBurst {
+	after := int64(1)
+	if burstDuration > 1 {
+		after = 1 + rng.Int63n(burstDuration-1)
+	}
+	return IOBurst{After: after, Duration: sampleBurst(rng, "exp", mean)}
+}
+
+// sampleBurst draws a single burst duration, in ticks, from dist with the
+// given mean. An unrecognized dist falls back to "exp".
+func sampleBurst(rng *rand.Rand, dist string, mean float64) int64 {
+	var v float64
+	switch dist {
+	case "lognormal":
+		// Choose a modest fixed shape parameter and solve for the location
+		// parameter that gives the lognormal the requested mean.
+		const sigma = 0.5
+		mu := math.Log(mean) - sigma*sigma/2
+		v = math.Exp(rng.NormFloat64()*sigma + mu)
+	default:
+		v = rng.ExpFloat64() * mean
+	}
+
+	if v < 1 {
+		v = 1
+	}
+	return int64(math.Round(v))
+}
+
+// samplePriority draws a priority level from 1..len(weights), weighted by
+// weights. An empty weights always returns priority 1.
+func samplePriority(rng *rand.Rand, weights []float64) int64 {
+	if len(weights) == 0 {
+		return 1
+	}
+
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+
+	r := rng.Float64() * total
+	var cum float64
+	for i, w := range weights {
+		cum += w
+		if r <= cum {
+			return int64(i + 1)
+		}
+	}
+
+	return int64(len(weights))
+}
+
+// parsePriorityWeights parses a comma-separated list of discrete priority
+// distribution weights, e.g. "1,2,3,2,1".
+func parsePriorityWeights(s string) ([]float64, error) {
+	parts := strings.Split(s, ",")
+	weights := make([]float64, len(parts))
+	for i, p := range parts {
+		w, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%v: error parsing priority weight %q", err, p)
+		}
+		weights[i] = w
+	}
+
+	return weights, nil
+}
+
+// simEvent is one line of the -eventsOutputFilePath JSONL output: a
+// context switch onto the CPU, a preemption off of it, or a completion.
+type simEvent struct {
+	T         int64  `json:"t"`
+	Algo      string `json:"algo"`
+	PID       int64  `json:"pid"`
+	Event     string `json:"event"`
+	Remaining int64  `json:"remaining"`
+}
+
+// cycleStat is one line of the -cycleStatsOutputFilePath JSONL output: the
+// aggregate state of a scheduler's run at a single tick.
+type cycleStat struct {
+	T              int64  `json:"t"`
+	Algo           string `json:"algo"`
+	ReadyQueueLen  int64  `json:"ready_queue_len"`
+	RunningPID     int64  `json:"running_pid"`
+	CumulativeWait int64  `json:"cumulative_wait"`
+}
+
+// deriveEventsAndCycleStats replays gantt, the complete scheduling
+// decision a Scheduler made for processes, tick by tick to reconstruct
+// the per-event and per-cycle instrumentation runSimulate reports. The
+// Gantt chart already determines who runs at every tick, so no scheduler
+// needs to be changed to emit this directly.
+func deriveEventsAndCycleStats(algo string, processes []Process, gantt []TimeSlice) ([]simEvent, []cycleStat) {
+	var events []simEvent
+	var cycles []cycleStat
+	if len(gantt) == 0 {
+		return events, cycles
+	}
+
+	burstOf := make(map[int64]int64, len(processes))
+	ioBurstsOf := make(map[int64][]IOBurst, len(processes))
+	for _, p := range processes {
+		burstOf[p.ProcessID] = p.BurstDuration
+		ioBurstsOf[p.ProcessID] = p.IOBursts
+	}
+
+	var totalTicks int64
+	for _, slice := range gantt {
+		if slice.Stop > totalTicks {
+			totalTicks = slice.Stop
+		}
+	}
+
+	ranSoFar := make(map[int64]int64, len(processes))
+	ioIndex := make(map[int64]int, len(processes))
+	blockedRemaining := make(map[int64]int64, len(processes))
+	sliceIdx := 0
+	var cumulativeWait int64
+
+	for t := int64(0); t < totalTicks; t++ {
+		for sliceIdx < len(gantt) && gantt[sliceIdx].Stop <= t {
+			sliceIdx++
+		}
+
+		runningPID := int64(-1)
+		if sliceIdx < len(gantt) && gantt[sliceIdx].Start <= t {
+			slice := gantt[sliceIdx]
+			runningPID = slice.PID
+
+			if t == slice.Start {
+				events = append(events, simEvent{
+					T: t, Algo: algo, PID: runningPID, Event: "dispatch",
+					Remaining: burstOf[runningPID] - ranSoFar[runningPID],
+				})
+			}
+
+			ranSoFar[runningPID]++
+
+			bursts := ioBurstsOf[runningPID]
+			if idx := ioIndex[runningPID]; idx < len(bursts) && ranSoFar[runningPID] == bursts[idx].After {
+				// One extra tick, since the countdown at the end of this
+				// tick, which the process spent on the CPU, takes one off.
+				blockedRemaining[runningPID] = bursts[idx].Duration + 1
+				ioIndex[runningPID] = idx + 1
+			}
+
+			if t+1 == slice.Stop {
+				remaining := burstOf[runningPID] - ranSoFar[runningPID]
+				event := "preempt"
+				if remaining <= 0 {
+					event = "completion"
+				}
+				events = append(events, simEvent{T: t + 1, Algo: algo, PID: runningPID, Event: event, Remaining: remaining})
+			}
+		}
+
+		var readyQueueLen int64
+		for _, p := range processes {
+			if p.ArrivalTime <= t && ranSoFar[p.ProcessID] < p.BurstDuration && p.ProcessID != runningPID && blockedRemaining[p.ProcessID] <= 0 {
+				readyQueueLen++
+			}
+		}
+		cumulativeWait += readyQueueLen
+
+		cycles = append(cycles, cycleStat{
+			T: t, Algo: algo, ReadyQueueLen: readyQueueLen,
+			RunningPID: runningPID, CumulativeWait: cumulativeWait,
+		})
+
+		for pid, remaining := range blockedRemaining {
+			if remaining > 0 {
+				blockedRemaining[pid] = remaining - 1
+			}
+		}
+	}
+
+	return events, cycles
+}
+
+// writeJSONLines encodes each of items as its own line of w, e.g. for
+// -eventsOutputFilePath/-cycleStatsOutputFilePath.
+func writeJSONLines[T any](w io.Writer, items []T) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runSimulate is the "simulate" subcommand: it generates one or more
+// synthetic workloads from a WorkloadConfig and runs every selected
+// scheduler over each, optionally recording per-event and per-tick
+// instrumentation alongside the usual Gantt/schedule-table output.
+func runSimulate(args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+	lambda := fs.Float64("lambda", 1, "Poisson arrival rate, in processes per tick")
+	burstDist := fs.String("burst-dist", "exp", "burst duration distribution: exp or lognormal")
+	burstMean := fs.Float64("burst-mean", 8, "mean burst duration, in ticks")
+	priorityWeights := fs.String("priority-weights", "1,1,1,1,1", "comma-separated discrete priority distribution weights, one per priority level starting at 1")
+	ioBurstProb := fs.Float64("io-burst-prob", 0, "probability that a generated process performs a single I/O burst")
+	ioBurstMean := fs.Float64("io-burst-mean", 4, "mean duration, in ticks, of a generated I/O burst")
+	numProcesses := fs.Int("processes", 100, "number of processes to generate per replication")
+	replications := fs.Int("replications", 1, "number of independent replications to run")
+	seed := fs.Int64("seed", 1, "random seed for reproducibility")
+	quantum := fs.Int64("quantum", DefaultQuantum, "time quantum, in ticks, for the round-robin scheduler")
+	mlfqConfigPath := fs.String("mlfq-config", "", "path to a JSON config file for the MLFQ scheduler (see MLFQConfig)")
+	algorithms := fs.String("algorithms", defaultAlgorithms, "comma-separated list of schedulers to run: fcfs,sjf,priority,rr,mlfq")
+	eventsOutputFilePath := fs.String("eventsOutputFilePath", "", "write every context-switch/completion event as one JSON line to this path")
+	cycleStatsOutputFilePath := fs.String("cycleStatsOutputFilePath", "", "write per-tick aggregate stats as one JSON line to this path")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	weights, err := parsePriorityWeights(*priorityWeights)
+	if err != nil {
+		return err
+	}
+
+	mlfqConfig := DefaultMLFQConfig
+	if *mlfqConfigPath != "" {
+		mlfqConfig, err = loadMLFQConfig(*mlfqConfigPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	schedulers, err := resolveSchedulers(schedulerRegistry(*quantum, mlfqConfig), strings.Split(*algorithms, ","))
+	if err != nil {
+		return err
+	}
+
+	var eventsFile, cycleStatsFile *os.File
+	if *eventsOutputFilePath != "" {
+		eventsFile, err = os.Create(*eventsOutputFilePath)
+		if err != nil {
+			return fmt.Errorf("%v: error creating events output file", err)
+		}
+		defer eventsFile.Close()
+	}
+	if *cycleStatsOutputFilePath != "" {
+		cycleStatsFile, err = os.Create(*cycleStatsOutputFilePath)
+		if err != nil {
+			return fmt.Errorf("%v: error creating cycle stats output file", err)
+		}
+		defer cycleStatsFile.Close()
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	cfg := WorkloadConfig{
+		Lambda: *lambda, BurstDist: *burstDist, BurstMean: *burstMean, PriorityWeights: weights,
+		IOBurstProb: *ioBurstProb, IOBurstMean: *ioBurstMean,
+	}
+
+	for rep := 0; rep < *replications; rep++ {
+		processes := generateWorkload(cfg, *numProcesses, rng)
+
+		for _, sched := range schedulers {
+			gantt, rows, stats := sched.Run(processes)
+
+			outputTitle(os.Stdout, fmt.Sprintf("%s (replication %d)", sched.Name(), rep))
+			outputGantt(os.Stdout, gantt)
+			outputSchedule(os.Stdout, rows, stats.Wait, stats.IOWait, stats.Turnaround, stats.Throughput)
+
+			if eventsFile == nil && cycleStatsFile == nil {
+				continue
+			}
+
+			events, cycles := deriveEventsAndCycleStats(sched.Name(), processes, gantt)
+			if eventsFile != nil {
+				if err := writeJSONLines(eventsFile, events); err != nil {
+					return fmt.Errorf("%v: error writing events output", err)
+				}
+			}
+			if cycleStatsFile != nil {
+				if err := writeJSONLines(cycleStatsFile, cycles); err != nil {
+					return fmt.Errorf("%v: error writing cycle stats output", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}